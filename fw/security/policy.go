@@ -0,0 +1,25 @@
+package security
+
+import "crypto/tls"
+
+// Policy describes the transport security a service should apply to its
+// listener.
+type Policy struct {
+	IsEncrypted         bool
+	CertificateFilePath string
+	KeyFilePath         string
+
+	// RequireClientCert enables mutual TLS: the client must present a
+	// certificate signed by a CA in CAFilePath, verified according to
+	// ClientAuth.
+	RequireClientCert bool
+	CAFilePath        string
+	ClientAuth        tls.ClientAuthType
+
+	// MinTLSVersion and CipherSuites let operators pin the negotiated
+	// protocol version and restrict the TLS 1.2 cipher suites offered, to
+	// meet compliance requirements. Zero values fall back to Go's secure
+	// defaults.
+	MinTLSVersion uint16
+	CipherSuites  []uint16
+}
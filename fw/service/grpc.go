@@ -2,14 +2,18 @@ package service
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/short-d/app/fw/logger"
 	"github.com/short-d/app/fw/rpc"
 	"github.com/short-d/app/fw/security"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 )
 
 var _ Service = (*GRPC)(nil)
@@ -19,6 +23,10 @@ type GRPC struct {
 	gRPCApi    rpc.API
 	logger     logger.Logger
 	onShutdown func()
+
+	gateway        *gateway
+	metricsEnabled bool
+	healthServer   *health.Server
 }
 
 func (g GRPC) Stop(ctx context.Context, cancel context.CancelFunc) {
@@ -30,6 +38,12 @@ func (g GRPC) Stop(ctx context.Context, cancel context.CancelFunc) {
 		cancel()
 	}()
 
+	if g.gateway != nil {
+		g.gateway.stop(ctx)
+	}
+	if g.healthServer != nil {
+		g.healthServer.Shutdown()
+	}
 	g.gRPCServer.GracefulStop()
 }
 
@@ -48,6 +62,10 @@ func (g GRPC) StartAsync(port int) {
 		g.gRPCApi.RegisterServers(g.gRPCServer)
 		g.gRPCServer.Serve(lis)
 	}()
+
+	if g.gateway != nil {
+		g.gateway.startAsync(fmt.Sprintf("localhost:%d", port), g.logger)
+	}
 }
 
 func (g GRPC) StartAndWait(port int) {
@@ -61,26 +79,23 @@ func NewGRPC(
 	rpcAPI rpc.API,
 	securityPolicy security.Policy,
 	onShutdown func(),
+	opts ...grpc.ServerOption,
 ) (GRPC, error) {
-	server := grpc.NewServer()
-	if !securityPolicy.IsEncrypted {
-		return GRPC{
-			logger:     logger,
-			gRPCServer: server,
-			gRPCApi:    rpcAPI,
-		}, nil
-	}
+	// Options passed by the caller are appended last so they override the
+	// defaults, matching grpc.NewServer's last-option-wins behavior.
+	allOpts := append(defaultServerOptions(), opts...)
 
-	cred, err := credentials.NewServerTLSFromFile(
-		securityPolicy.CertificateFilePath,
-		securityPolicy.KeyFilePath,
-	)
-	if err != nil {
-		return GRPC{}, err
+	if securityPolicy.IsEncrypted {
+		cred, err := credentialsFromPolicy(securityPolicy)
+		if err != nil {
+			return GRPC{}, err
+		}
+		allOpts = append(allOpts, grpc.Creds(cred))
 	}
 
+	server := grpc.NewServer(allOpts...)
 	return GRPC{
-		gRPCServer: grpc.NewServer(grpc.Creds(cred)),
+		gRPCServer: server,
 		gRPCApi:    rpcAPI,
 		logger:     logger,
 		onShutdown: onShutdown,
@@ -99,13 +114,43 @@ func (a api) RegisterServers(server *grpc.Server) {
 	a.registerHandler(server)
 }
 
+// GatewayRegisterFunc registers the REST/JSON handlers that proxy incoming
+// HTTP requests to a gRPC endpoint. It mirrors the generated
+// `Register<Service>HandlerFromEndpoint` functions produced by
+// protoc-gen-grpc-gateway.
+type GatewayRegisterFunc func(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error
+
 type GRPCBuilder struct {
 	logger          logger.Logger
 	enableTLS       bool
 	certPath        string
 	keyPath         string
+	caFilePath      string
+	clientAuth      tls.ClientAuthType
+	minTLSVersion   uint16
+	cipherSuites    []uint16
 	registerHandler registerHandler
 	onShutdown      func()
+
+	gatewayEnabled    bool
+	gatewayHTTPPort   int
+	gatewayRegister   GatewayRegisterFunc
+	swaggerUIEnabled  bool
+	swaggerUISubPath  string
+	swaggerUISpecPath string
+
+	unaryInterceptors  []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
+	metricsEnabled     bool
+
+	healthChecksEnabled bool
+	reflectionEnabled   bool
+
+	keepaliveParams      *keepalive.ServerParameters
+	keepalivePolicy      *keepalive.EnforcementPolicy
+	maxRecvMsgSize       int
+	maxSendMsgSize       int
+	maxConcurrentStreams uint32
 }
 
 func (g *GRPCBuilder) EnableTLS(certPath string, keyPath string) *GRPCBuilder {
@@ -120,14 +165,67 @@ func (g *GRPCBuilder) RegisterHandler(handler registerHandler) *GRPCBuilder {
 	return g
 }
 
+// EnableGateway boots a REST/JSON gateway alongside the gRPC server. Incoming
+// HTTP requests on httpPort are transcoded to gRPC calls against the server
+// started by StartAsync/StartAndWait, sharing its TLS credentials whenever
+// EnableTLS has been configured.
+func (g *GRPCBuilder) EnableGateway(httpPort int, register GatewayRegisterFunc) *GRPCBuilder {
+	g.gatewayEnabled = true
+	g.gatewayHTTPPort = httpPort
+	g.gatewayRegister = register
+	return g
+}
+
+// EnableSwaggerUI serves a Swagger UI page at subPath, backed by the OpenAPI
+// document at specFilePath. It requires EnableGateway. The UI's scripts and
+// styles are not embedded: the page loads them from the unpkg CDN.
+func (g *GRPCBuilder) EnableSwaggerUI(subPath string, specFilePath string) *GRPCBuilder {
+	g.swaggerUIEnabled = true
+	g.swaggerUISubPath = subPath
+	g.swaggerUISpecPath = specFilePath
+	return g
+}
+
 func (g *GRPCBuilder) Build() (GRPC, error) {
 	rpcAPI := api{registerHandler: g.registerHandler}
 	policy := security.Policy{
 		IsEncrypted:         g.enableTLS,
 		CertificateFilePath: g.certPath,
 		KeyFilePath:         g.keyPath,
+		RequireClientCert:   g.caFilePath != "",
+		CAFilePath:          g.caFilePath,
+		ClientAuth:          g.clientAuth,
+		MinTLSVersion:       g.minTLSVersion,
+		CipherSuites:        g.cipherSuites,
+	}
+
+	opts := append(g.serverOptions(), chainedServerOptions(g.unaryInterceptors, g.streamInterceptors)...)
+
+	gRPCServer, err := NewGRPC(g.logger, rpcAPI, policy, g.onShutdown, opts...)
+	if err != nil {
+		return GRPC{}, err
+	}
+	gRPCServer.metricsEnabled = g.metricsEnabled
+
+	if g.healthChecksEnabled {
+		gRPCServer.healthServer = registerHealthChecks(gRPCServer.gRPCServer)
+	}
+	if g.reflectionEnabled {
+		reflection.Register(gRPCServer.gRPCServer)
+	}
+
+	if g.gatewayEnabled {
+		gw, err := newGateway(g.gatewayHTTPPort, g.gatewayRegister, policy, gatewaySwaggerOptions{
+			enabled:  g.swaggerUIEnabled,
+			subPath:  g.swaggerUISubPath,
+			specPath: g.swaggerUISpecPath,
+		}, g.metricsEnabled)
+		if err != nil {
+			return GRPC{}, err
+		}
+		gRPCServer.gateway = gw
 	}
-	return NewGRPC(g.logger, rpcAPI, policy, g.onShutdown)
+	return gRPCServer, nil
 }
 
 func NewGRPCBuilder(name string, onShutdown func()) *GRPCBuilder {
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/short-d/app/fw/logger"
+	"github.com/short-d/app/fw/security"
+	"google.golang.org/grpc"
+)
+
+type gatewaySwaggerOptions struct {
+	enabled  bool
+	subPath  string
+	specPath string
+}
+
+// gateway runs an HTTP server that transcodes REST/JSON requests into gRPC
+// calls against the server started by GRPC.StartAsync, optionally serving an
+// OpenAPI/Swagger UI alongside it.
+type gateway struct {
+	httpPort       int
+	register       GatewayRegisterFunc
+	policy         security.Policy
+	swagger        gatewaySwaggerOptions
+	metricsEnabled bool
+
+	httpServer *http.Server
+}
+
+func newGateway(
+	httpPort int,
+	register GatewayRegisterFunc,
+	policy security.Policy,
+	swagger gatewaySwaggerOptions,
+	metricsEnabled bool,
+) (*gateway, error) {
+	return &gateway{
+		httpPort:       httpPort,
+		register:       register,
+		policy:         policy,
+		swagger:        swagger,
+		metricsEnabled: metricsEnabled,
+	}, nil
+}
+
+func (g *gateway) startAsync(grpcEndpoint string, lg logger.Logger) {
+	mux := runtime.NewServeMux()
+
+	dialOpts := []grpc.DialOption{grpc.WithInsecure()}
+	if g.policy.IsEncrypted {
+		cred, err := dialCredentialsFromPolicy(g.policy)
+		if err != nil {
+			lg.Error(err)
+			panic(err)
+		}
+		dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(cred)}
+	}
+
+	if err := g.register(context.Background(), mux, grpcEndpoint, dialOpts); err != nil {
+		lg.Error(err)
+		panic(err)
+	}
+
+	handler := http.Handler(mux)
+	if g.swagger.enabled {
+		handler = withSwaggerUI(mux, g.swagger.subPath, g.swagger.specPath)
+	}
+	if g.metricsEnabled {
+		handler = withMetrics(handler)
+	}
+
+	g.httpServer = &http.Server{
+		Addr:    fmt.Sprintf(":%d", g.httpPort),
+		Handler: handler,
+	}
+
+	go func() {
+		msg := fmt.Sprintf("gRPC-Gateway started at localhost:%d", g.httpPort)
+		lg.Info(msg)
+
+		if err := g.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			lg.Error(err)
+		}
+	}()
+}
+
+func (g *gateway) stop(ctx context.Context) {
+	if g.httpServer == nil {
+		return
+	}
+	g.httpServer.Shutdown(ctx)
+}
+
+func withMetrics(next http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/", next)
+	mux.Handle("/metrics", promhttp.Handler())
+	return mux
+}
+
+// withSwaggerUI mounts an interactive Swagger UI at subPath, loading
+// swagger-ui-dist from a CDN and pointing it at the OpenAPI document read
+// from specPath and served at <subPath>/openapi.json.
+func withSwaggerUI(next http.Handler, subPath string, specPath string) http.Handler {
+	specRoute := strings.TrimSuffix(subPath, "/") + "/openapi.json"
+
+	mux := http.NewServeMux()
+	mux.Handle("/", next)
+	mux.HandleFunc(specRoute, func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, specPath)
+	})
+	mux.HandleFunc(subPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, swaggerUIHTML, specRoute)
+	})
+	return mux
+}
+
+const swaggerUIHTML = `<!DOCTYPE html>
+<html>
+<head>
+  <title>API Docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => {
+      SwaggerUIBundle({
+        url: %q,
+        dom_id: "#swagger-ui",
+      });
+    };
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// EnableHealthChecks registers the standard gRPC health checking service,
+// allowing load balancers and orchestrators to probe readiness via
+// healthpb.HealthClient.
+func (g *GRPCBuilder) EnableHealthChecks() *GRPCBuilder {
+	g.healthChecksEnabled = true
+	return g
+}
+
+// EnableReflection registers server reflection, letting tools such as
+// grpcurl or BloomRPC introspect the API without shipping .proto files.
+func (g *GRPCBuilder) EnableReflection() *GRPCBuilder {
+	g.reflectionEnabled = true
+	return g
+}
+
+// SetServingStatus updates the serving status reported by the health
+// checking service for the given service name. It is a no-op when
+// EnableHealthChecks was not called.
+func (g GRPC) SetServingStatus(service string, status healthpb.HealthCheckResponse_ServingStatus) {
+	if g.healthServer == nil {
+		return
+	}
+	g.healthServer.SetServingStatus(service, status)
+}
+
+func registerHealthChecks(server *grpc.Server) *health.Server {
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	return healthServer
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/short-d/app/fw/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WithUnaryInterceptors appends interceptors to the chain applied to every
+// unary RPC, in the order given.
+func (g *GRPCBuilder) WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) *GRPCBuilder {
+	g.unaryInterceptors = append(g.unaryInterceptors, interceptors...)
+	return g
+}
+
+// WithStreamInterceptors appends interceptors to the chain applied to every
+// streaming RPC, in the order given.
+func (g *GRPCBuilder) WithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) *GRPCBuilder {
+	g.streamInterceptors = append(g.streamInterceptors, interceptors...)
+	return g
+}
+
+// WithRecovery installs an interceptor that converts panics inside RPC
+// handlers into a codes.Internal error instead of crashing the process.
+func (g *GRPCBuilder) WithRecovery() *GRPCBuilder {
+	opt := grpc_recovery.WithRecoveryHandlerContext(func(ctx context.Context, p interface{}) error {
+		g.logger.Error(fmt.Errorf("panic recovered: %v\n%s", p, debug.Stack()))
+		return status.Errorf(codes.Internal, "internal server error")
+	})
+	g.unaryInterceptors = append(g.unaryInterceptors, grpc_recovery.UnaryServerInterceptor(opt))
+	g.streamInterceptors = append(g.streamInterceptors, grpc_recovery.StreamServerInterceptor(opt))
+	return g
+}
+
+// WithRequestLogging installs an interceptor that logs every RPC call
+// through lg.
+func (g *GRPCBuilder) WithRequestLogging(lg logger.Logger) *GRPCBuilder {
+	g.unaryInterceptors = append(g.unaryInterceptors, func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			lg.Error(fmt.Errorf("%s: %w", info.FullMethod, err))
+		} else {
+			lg.Info(fmt.Sprintf("handled %s", info.FullMethod))
+		}
+		return resp, err
+	})
+	g.streamInterceptors = append(g.streamInterceptors, func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		err := handler(srv, ss)
+		if err != nil {
+			lg.Error(fmt.Errorf("%s: %w", info.FullMethod, err))
+		} else {
+			lg.Info(fmt.Sprintf("handled %s", info.FullMethod))
+		}
+		return err
+	})
+	return g
+}
+
+// WithPrometheusMetrics registers grpc_prometheus histograms for every RPC.
+// The resulting handler is available through GRPC.MetricsHandler, and is
+// mounted automatically at /metrics on the REST gateway when EnableGateway
+// is also used.
+func (g *GRPCBuilder) WithPrometheusMetrics() *GRPCBuilder {
+	grpc_prometheus.EnableHandlingTimeHistogram()
+	g.unaryInterceptors = append(g.unaryInterceptors, grpc_prometheus.UnaryServerInterceptor)
+	g.streamInterceptors = append(g.streamInterceptors, grpc_prometheus.StreamServerInterceptor)
+	g.metricsEnabled = true
+	return g
+}
+
+// WithAuthFunc installs an interceptor that authenticates every RPC call by
+// calling authenticate with the incoming context, replacing it with the
+// context authenticate returns. Returning an error rejects the call with that
+// error: a status error keeps its code, and any other error reaches the
+// client as codes.Unknown.
+func (g *GRPCBuilder) WithAuthFunc(authenticate func(ctx context.Context) (context.Context, error)) *GRPCBuilder {
+	g.unaryInterceptors = append(g.unaryInterceptors, grpc_auth.UnaryServerInterceptor(authenticate))
+	g.streamInterceptors = append(g.streamInterceptors, grpc_auth.StreamServerInterceptor(authenticate))
+	return g
+}
+
+// MetricsHandler exposes the Prometheus handler registered by
+// GRPCBuilder.WithPrometheusMetrics, or nil if metrics were never enabled.
+func (g GRPC) MetricsHandler() http.Handler {
+	if !g.metricsEnabled {
+		return nil
+	}
+	return promhttp.Handler()
+}
+
+func chainedServerOptions(unary []grpc.UnaryServerInterceptor, stream []grpc.StreamServerInterceptor) []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if len(unary) > 0 {
+		opts = append(opts, grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unary...)))
+	}
+	if len(stream) > 0 {
+		opts = append(opts, grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(stream...)))
+	}
+	return opts
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Info(msg string) {}
+func (noopLogger) Error(err error) {}
+
+func passthroughUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(ctx, req)
+}
+
+func passthroughStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, ss)
+}
+
+func TestChainedServerOptions(t *testing.T) {
+	testCases := []struct {
+		name    string
+		unary   []grpc.UnaryServerInterceptor
+		stream  []grpc.StreamServerInterceptor
+		wantLen int
+	}{
+		{"empty", nil, nil, 0},
+		{"unaryOnly", []grpc.UnaryServerInterceptor{passthroughUnary}, nil, 1},
+		{"streamOnly", nil, []grpc.StreamServerInterceptor{passthroughStream}, 1},
+		{"both", []grpc.UnaryServerInterceptor{passthroughUnary}, []grpc.StreamServerInterceptor{passthroughStream}, 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := chainedServerOptions(tc.unary, tc.stream)
+			if len(opts) != tc.wantLen {
+				t.Errorf("chainedServerOptions() returned %d options, want %d", len(opts), tc.wantLen)
+			}
+		})
+	}
+}
+
+func TestGRPCBuilder_WithUnaryInterceptors_AccumulatesAcrossCalls(t *testing.T) {
+	builder := &GRPCBuilder{logger: noopLogger{}}
+	builder.WithUnaryInterceptors(passthroughUnary)
+	builder.WithUnaryInterceptors(passthroughUnary, passthroughUnary)
+
+	if got := len(builder.unaryInterceptors); got != 3 {
+		t.Fatalf("expected 3 accumulated unary interceptors, got %d", got)
+	}
+}
+
+func TestGRPCBuilder_WithRecovery_ConvertsPanicToInternalError(t *testing.T) {
+	builder := &GRPCBuilder{logger: noopLogger{}}
+	builder.WithRecovery()
+
+	if got := len(builder.unaryInterceptors); got != 1 {
+		t.Fatalf("expected WithRecovery to register one unary interceptor, got %d", got)
+	}
+
+	interceptor := builder.unaryInterceptors[0]
+	_, err := interceptor(
+		context.Background(),
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			panic("boom")
+		},
+	)
+
+	if status.Code(err) != codes.Internal {
+		t.Errorf("expected a panic to be converted to codes.Internal, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	defaultKeepaliveIdle    = 5 * time.Minute
+	defaultKeepaliveTime    = 1 * time.Minute
+	defaultKeepaliveTimeout = 20 * time.Second
+)
+
+// WithKeepalive overrides the default keepalive parameters and enforcement
+// policy, guarding against idle-connection buildup from misbehaving or
+// disconnected clients.
+func (g *GRPCBuilder) WithKeepalive(params keepalive.ServerParameters, policy keepalive.EnforcementPolicy) *GRPCBuilder {
+	g.keepaliveParams = &params
+	g.keepalivePolicy = &policy
+	return g
+}
+
+// WithMaxRecvMsgSize overrides the default 4 MiB cap on received message
+// size.
+func (g *GRPCBuilder) WithMaxRecvMsgSize(bytes int) *GRPCBuilder {
+	g.maxRecvMsgSize = bytes
+	return g
+}
+
+// WithMaxSendMsgSize overrides the default cap on sent message size.
+func (g *GRPCBuilder) WithMaxSendMsgSize(bytes int) *GRPCBuilder {
+	g.maxSendMsgSize = bytes
+	return g
+}
+
+// WithMaxConcurrentStreams limits the number of concurrent streams per
+// client connection.
+func (g *GRPCBuilder) WithMaxConcurrentStreams(n uint32) *GRPCBuilder {
+	g.maxConcurrentStreams = n
+	return g
+}
+
+// WithHandlerTimeout installs a unary interceptor that cancels a handler's
+// context if it runs longer than timeout.
+func (g *GRPCBuilder) WithHandlerTimeout(timeout time.Duration) *GRPCBuilder {
+	g.unaryInterceptors = append(g.unaryInterceptors, func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return handler(ctx, req)
+	})
+	return g
+}
+
+// defaultServerOptions returns the keepalive defaults NewGRPC applies to
+// every server unless the caller supplies its own options afterward.
+func defaultServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle: defaultKeepaliveIdle,
+			Time:              defaultKeepaliveTime,
+			Timeout:           defaultKeepaliveTimeout,
+		}),
+	}
+}
+
+func (g *GRPCBuilder) serverOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if g.keepaliveParams != nil {
+		opts = append(opts, grpc.KeepaliveParams(*g.keepaliveParams))
+	}
+	if g.keepalivePolicy != nil {
+		opts = append(opts, grpc.KeepaliveEnforcementPolicy(*g.keepalivePolicy))
+	}
+	if g.maxRecvMsgSize != 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(g.maxRecvMsgSize))
+	}
+	if g.maxSendMsgSize != 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(g.maxSendMsgSize))
+	}
+	if g.maxConcurrentStreams != 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(g.maxConcurrentStreams))
+	}
+	return opts
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+func TestDefaultServerOptions_AppliesKeepaliveDefault(t *testing.T) {
+	opts := defaultServerOptions()
+	if len(opts) != 1 {
+		t.Fatalf("expected exactly one default server option (keepalive params), got %d", len(opts))
+	}
+}
+
+func TestGRPCBuilder_ServerOptions_OnlyIncludesConfiguredOverrides(t *testing.T) {
+	builder := &GRPCBuilder{}
+
+	if got := len(builder.serverOptions()); got != 0 {
+		t.Fatalf("expected no server options for a zero-value builder, got %d", got)
+	}
+
+	builder.WithMaxRecvMsgSize(1024)
+	if got := len(builder.serverOptions()); got != 1 {
+		t.Fatalf("expected one server option after WithMaxRecvMsgSize, got %d", got)
+	}
+
+	builder.WithMaxSendMsgSize(2048)
+	builder.WithMaxConcurrentStreams(10)
+	if got := len(builder.serverOptions()); got != 3 {
+		t.Fatalf("expected three server options after Max* overrides, got %d", got)
+	}
+
+	params := keepalive.ServerParameters{Time: time.Minute}
+	policy := keepalive.EnforcementPolicy{MinTime: time.Second}
+	builder.WithKeepalive(params, policy)
+	if got := len(builder.serverOptions()); got != 5 {
+		t.Fatalf("expected five server options after WithKeepalive, got %d", got)
+	}
+}
+
+func TestGRPCBuilder_WithHandlerTimeout_CancelsSlowHandlers(t *testing.T) {
+	builder := &GRPCBuilder{}
+	builder.WithHandlerTimeout(10 * time.Millisecond)
+
+	if got := len(builder.unaryInterceptors); got != 1 {
+		t.Fatalf("expected WithHandlerTimeout to register one unary interceptor, got %d", got)
+	}
+
+	interceptor := builder.unaryInterceptors[0]
+
+	var handlerCtxErr error
+	_, _ = interceptor(
+		context.Background(),
+		nil,
+		&grpc.UnaryServerInfo{},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			select {
+			case <-time.After(200 * time.Millisecond):
+			case <-ctx.Done():
+				handlerCtxErr = ctx.Err()
+			}
+			return nil, nil
+		},
+	)
+
+	if handlerCtxErr != context.DeadlineExceeded {
+		t.Errorf("expected the handler's context to be canceled with DeadlineExceeded, got %v", handlerCtxErr)
+	}
+}
+
+func TestGRPCBuilder_WithHandlerTimeout_LeavesFastHandlersUncancelled(t *testing.T) {
+	builder := &GRPCBuilder{}
+	builder.WithHandlerTimeout(200 * time.Millisecond)
+
+	interceptor := builder.unaryInterceptors[0]
+
+	resp, err := interceptor(
+		context.Background(),
+		nil,
+		&grpc.UnaryServerInfo{},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "ok", nil
+		},
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp != "ok" {
+		t.Errorf("expected handler response to pass through, got %v", resp)
+	}
+}
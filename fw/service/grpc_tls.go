@@ -0,0 +1,112 @@
+package service
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/short-d/app/fw/security"
+	"google.golang.org/grpc/credentials"
+)
+
+// EnableMTLS configures mutual TLS: in addition to the server presenting
+// certPath/keyPath, clients must present a certificate signed by a CA in
+// caFilePath, verified according to clientAuth (e.g.
+// tls.RequireAndVerifyClientCert).
+func (g *GRPCBuilder) EnableMTLS(certPath string, keyPath string, caFilePath string, clientAuth tls.ClientAuthType) *GRPCBuilder {
+	g.enableTLS = true
+	g.certPath = certPath
+	g.keyPath = keyPath
+	g.caFilePath = caFilePath
+	g.clientAuth = clientAuth
+	return g
+}
+
+// WithMinTLSVersion pins the minimum TLS protocol version negotiated by the
+// server, e.g. tls.VersionTLS12.
+func (g *GRPCBuilder) WithMinTLSVersion(version uint16) *GRPCBuilder {
+	g.minTLSVersion = version
+	return g
+}
+
+// WithCipherSuites restricts the TLS 1.2 cipher suites the server is willing
+// to negotiate. It has no effect on TLS 1.3, which does not allow the
+// cipher suite to be configured.
+func (g *GRPCBuilder) WithCipherSuites(cipherSuites ...uint16) *GRPCBuilder {
+	g.cipherSuites = cipherSuites
+	return g
+}
+
+func credentialsFromPolicy(policy security.Policy) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(policy.CertificateFilePath, policy.KeyFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   policy.MinTLSVersion,
+		CipherSuites: policy.CipherSuites,
+	}
+
+	if policy.RequireClientCert {
+		caPool, err := loadCAPool(policy.CAFilePath)
+		if err != nil {
+			return nil, err
+		}
+
+		clientAuth := policy.ClientAuth
+		if clientAuth == tls.NoClientCert {
+			clientAuth = tls.RequireAndVerifyClientCert
+		}
+
+		tlsConfig.ClientCAs = caPool
+		tlsConfig.ClientAuth = clientAuth
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// dialCredentialsFromPolicy builds the client-side credentials the REST
+// gateway uses to dial the gRPC server it proxies to. Without mTLS it trusts
+// the server's own certificate. When the server requires a client
+// certificate (mTLS), the gateway presents the server's certificate and key
+// as its client certificate and verifies the server against the CA pool in
+// CAFilePath.
+func dialCredentialsFromPolicy(policy security.Policy) (credentials.TransportCredentials, error) {
+	if !policy.RequireClientCert {
+		return credentials.NewClientTLSFromFile(policy.CertificateFilePath, "")
+	}
+
+	cert, err := tls.LoadX509KeyPair(policy.CertificateFilePath, policy.KeyFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	caPool, err := loadCAPool(policy.CAFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      caPool,
+		MinVersion:   policy.MinTLSVersion,
+		CipherSuites: policy.CipherSuites,
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+func loadCAPool(caFilePath string) (*x509.CertPool, error) {
+	caCert, err := ioutil.ReadFile(caFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("failed to parse CA certificate from %s", caFilePath)
+	}
+	return caPool, nil
+}
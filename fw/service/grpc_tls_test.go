@@ -0,0 +1,148 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/short-d/app/fw/security"
+)
+
+// generateTestCert writes a self-signed certificate and key pair to dir,
+// returning their paths.
+func generateTestCert(t *testing.T, dir, name string) (certPath string, keyPath string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		IsCA:         true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	certPath = filepath.Join(dir, name+".crt")
+	keyPath = filepath.Join(dir, name+".key")
+
+	certOut, err := os.Create(certPath)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", certPath, err)
+	}
+	defer certOut.Close()
+	pem.Encode(certOut, &pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	keyOut, err := os.Create(keyPath)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", keyPath, err)
+	}
+	defer keyOut.Close()
+	pem.Encode(keyOut, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	return certPath, keyPath
+}
+
+func TestCredentialsFromPolicy_PlainTLS_WithMinVersionAndCipherSuites_ReturnsTLSCredentials(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := generateTestCert(t, dir, "server")
+
+	policy := security.Policy{
+		IsEncrypted:         true,
+		CertificateFilePath: certPath,
+		KeyFilePath:         keyPath,
+		MinTLSVersion:       tls.VersionTLS12,
+		CipherSuites:        []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+	}
+
+	cred, err := credentialsFromPolicy(policy)
+	if err != nil {
+		t.Fatalf("credentialsFromPolicy() returned error: %v", err)
+	}
+	if cred == nil {
+		t.Fatal("credentialsFromPolicy() returned nil credentials")
+	}
+
+	info := cred.Info()
+	if info.SecurityProtocol != "tls" {
+		t.Errorf("expected tls security protocol, got %q", info.SecurityProtocol)
+	}
+}
+
+func TestCredentialsFromPolicy_MissingCAFile_ReturnsError(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := generateTestCert(t, dir, "server")
+
+	policy := security.Policy{
+		IsEncrypted:         true,
+		CertificateFilePath: certPath,
+		KeyFilePath:         keyPath,
+		RequireClientCert:   true,
+		CAFilePath:          filepath.Join(dir, "does-not-exist.crt"),
+	}
+
+	if _, err := credentialsFromPolicy(policy); err == nil {
+		t.Fatal("expected an error when the CA file does not exist")
+	}
+}
+
+func TestCredentialsFromPolicy_MTLS_ZeroClientAuth_BuildsCredentials(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := generateTestCert(t, dir, "server")
+	caPath, _ := generateTestCert(t, dir, "ca")
+
+	policy := security.Policy{
+		IsEncrypted:         true,
+		CertificateFilePath: certPath,
+		KeyFilePath:         keyPath,
+		RequireClientCert:   true,
+		CAFilePath:          caPath,
+	}
+
+	cred, err := credentialsFromPolicy(policy)
+	if err != nil {
+		t.Fatalf("credentialsFromPolicy() returned error: %v", err)
+	}
+	if cred == nil {
+		t.Fatal("credentialsFromPolicy() returned nil credentials")
+	}
+}
+
+func TestDialCredentialsFromPolicy_MTLS_LoadsClientCertAndCAPool(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := generateTestCert(t, dir, "client")
+	caPath, _ := generateTestCert(t, dir, "ca")
+
+	policy := security.Policy{
+		IsEncrypted:         true,
+		CertificateFilePath: certPath,
+		KeyFilePath:         keyPath,
+		RequireClientCert:   true,
+		CAFilePath:          caPath,
+	}
+
+	cred, err := dialCredentialsFromPolicy(policy)
+	if err != nil {
+		t.Fatalf("dialCredentialsFromPolicy() returned error: %v", err)
+	}
+	if cred == nil {
+		t.Fatal("dialCredentialsFromPolicy() returned nil credentials")
+	}
+}
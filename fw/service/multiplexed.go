@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/short-d/app/fw/logger"
+	"github.com/short-d/app/fw/rpc"
+	"github.com/soheilhy/cmux"
+	"google.golang.org/grpc"
+)
+
+var _ Service = (*Multiplexed)(nil)
+
+// Multiplexed serves a gRPC API and an HTTP handler from a single TCP
+// listener, so both can sit behind one firewall/ingress port. HTTP/2
+// requests carrying the gRPC content-type are routed to the gRPC server;
+// everything else goes to httpHandler.
+type Multiplexed struct {
+	gRPCServer *grpc.Server
+	gRPCApi    rpc.API
+	httpServer *http.Server
+	logger     logger.Logger
+	onShutdown func()
+}
+
+func (m Multiplexed) Stop(ctx context.Context, cancel context.CancelFunc) {
+	defer m.logger.Info("multiplexed service stopped")
+	defer func() {
+		if m.onShutdown != nil {
+			m.onShutdown()
+		}
+		cancel()
+	}()
+
+	m.httpServer.Shutdown(ctx)
+	m.gRPCServer.GracefulStop()
+}
+
+func (m Multiplexed) StartAsync(port int) {
+	msg := fmt.Sprintf("multiplexed gRPC+HTTP service started at localhost:%d", port)
+	defer m.logger.Info(msg)
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		m.logger.Error(err)
+		panic(err)
+	}
+
+	rootMux := cmux.New(lis)
+	grpcLis := rootMux.Match(cmux.HTTP2HeaderField("content-type", "application/grpc"))
+	httpLis := rootMux.Match(cmux.Any())
+
+	m.gRPCApi.RegisterServers(m.gRPCServer)
+
+	go m.gRPCServer.Serve(grpcLis)
+	go m.httpServer.Serve(httpLis)
+	go func() {
+		if err := rootMux.Serve(); err != nil {
+			m.logger.Error(err)
+		}
+	}()
+}
+
+func (m Multiplexed) StartAndWait(port int) {
+	m.StartAsync(port)
+
+	listenForSignals(m)
+}
+
+// NewMultiplexed creates a Multiplexed service from an already configured
+// gRPC server and an HTTP handler serving everything else.
+func NewMultiplexed(
+	logger logger.Logger,
+	rpcAPI rpc.API,
+	gRPCServer *grpc.Server,
+	httpHandler http.Handler,
+	onShutdown func(),
+) Multiplexed {
+	return Multiplexed{
+		gRPCServer: gRPCServer,
+		gRPCApi:    rpcAPI,
+		httpServer: &http.Server{Handler: httpHandler},
+		logger:     logger,
+		onShutdown: onShutdown,
+	}
+}
+
+// ShareListenerWith builds the gRPC server configured so far and returns a
+// Multiplexed service that routes gRPC traffic to it and everything else to
+// httpHandler over a single shared TCP listener.
+//
+// It refuses to build when EnableGateway or EnableHealthChecks were also
+// configured: neither the REST gateway nor GRPC.SetServingStatus carries
+// over to Multiplexed, so silently dropping them would leave those features
+// configured but never running. It also refuses TLS/mTLS: cmux's
+// content-type sniffing reads the plaintext HTTP/2 frame, so once the
+// connection is encrypted the matcher can never see it and every connection
+// would silently fall through to the plain HTTP server instead of gRPC.
+func (g *GRPCBuilder) ShareListenerWith(httpHandler http.Handler) (Multiplexed, error) {
+	if g.gatewayEnabled {
+		return Multiplexed{}, fmt.Errorf("ShareListenerWith: EnableGateway is not supported alongside connection multiplexing")
+	}
+	if g.healthChecksEnabled {
+		return Multiplexed{}, fmt.Errorf("ShareListenerWith: EnableHealthChecks is not supported alongside connection multiplexing")
+	}
+	if g.enableTLS {
+		return Multiplexed{}, fmt.Errorf("ShareListenerWith: EnableTLS/EnableMTLS is not supported alongside connection multiplexing")
+	}
+
+	gRPCServer, err := g.Build()
+	if err != nil {
+		return Multiplexed{}, err
+	}
+	return NewMultiplexed(g.logger, gRPCServer.gRPCApi, gRPCServer.gRPCServer, httpHandler, g.onShutdown), nil
+}